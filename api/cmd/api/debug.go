@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+)
+
+func init() {
+	// net/http/pprof registers its handlers on http.DefaultServeMux as a
+	// side effect of being imported. Zero it out immediately so nothing
+	// ever gets served from it, and mount pprof explicitly on our own
+	// private debug mux instead.
+	http.DefaultServeMux = http.NewServeMux()
+}
+
+// debugRoutes returns the handler for the private debug/admin server. It is
+// never exposed on the public API listener.
+func (app *application) debugRoutes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/version", app.debugVersionHandler)
+
+	return mux
+}
+
+// debugVersionHandler reports the running build's version, Go runtime
+// version, and VCS build info, for identifying what's actually deployed.
+func (app *application) debugVersionHandler(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Version   string `json:"version"`
+		GoVersion string `json:"go_version"`
+		BuildInfo string `json:"build_info,omitempty"`
+	}{
+		Version:   version,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		data.BuildInfo = bi.String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		app.logger.Error(err.Error())
+	}
+}
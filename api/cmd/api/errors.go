@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+)
+
+// errorResponse writes a JSON error envelope of the form
+// {"error": "...", "details": {...}}. details may be nil.
+func (app *application) errorResponse(w http.ResponseWriter, r *http.Request, status int, message any, details any) {
+	env := envelope{"error": message}
+	if details != nil {
+		env["details"] = details
+	}
+
+	if err := app.writeJSON(w, status, env, nil); err != nil {
+		app.logger.Error(err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// serverErrorResponse logs the underlying error and returns a generic 500
+// so internal details never leak to the client.
+func (app *application) serverErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	app.logger.Error(err.Error(), "method", r.Method, "path", r.URL.Path)
+
+	message := "the server encountered a problem and could not process your request"
+	app.errorResponse(w, r, http.StatusInternalServerError, message, nil)
+}
+
+// notFoundResponse returns a 404 for routes that don't match any handler.
+func (app *application) notFoundResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the requested resource could not be found"
+	app.errorResponse(w, r, http.StatusNotFound, message, nil)
+}
+
+// methodNotAllowedResponse returns a 405 for a recognised route called with
+// an unsupported method.
+func (app *application) methodNotAllowedResponse(w http.ResponseWriter, r *http.Request) {
+	message := "the " + r.Method + " method is not supported for this resource"
+	app.errorResponse(w, r, http.StatusMethodNotAllowed, message, nil)
+}
+
+// badRequestResponse returns a 400 with the validation or parsing error that
+// caused the request to be rejected.
+func (app *application) badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
+	app.errorResponse(w, r, http.StatusBadRequest, err.Error(), nil)
+}
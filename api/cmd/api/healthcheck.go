@@ -0,0 +1,22 @@
+package main
+
+import "net/http"
+
+func (app *application) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		app.methodNotAllowedResponse(w, r)
+		return
+	}
+
+	data := envelope{
+		"status": "available",
+		"system_info": map[string]string{
+			"environment": app.config.Env,
+			"version":     version,
+		},
+	}
+
+	if err := app.writeJSON(w, http.StatusOK, data, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
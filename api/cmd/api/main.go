@@ -1,32 +1,39 @@
 package main
 
 import (
-	"flag"
+	"encoding/json"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
-	"time"
+	"sync"
+
+	"github.com/arvindeva/sortr/api/internal/config"
 )
 
 const version = "1"
 
-type config struct {
-	port int
-	env  string
-}
-
 type application struct {
-	config config
+	config config.Config
 	logger *slog.Logger
+	wg     sync.WaitGroup
 }
 
 func main() {
-	var cfg config
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-	flag.IntVar(&cfg.port, "port", 8080, "API server port")
-	flag.StringVar(&cfg.env, "env", "development", "Environment (development|staging|production)")
-	flag.Parse()
+	if cfg.DumpConfig {
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		os.Exit(0)
+	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
@@ -34,22 +41,10 @@ func main() {
 		config: cfg,
 		logger: logger,
 	}
-	router := http.NewServeMux()
-	router.HandleFunc("/healthcheck", app.healthCheckHandler)
-
-	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.port),
-		Handler:      router,
-		IdleTimeout:  time.Minute,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
-	}
 
-	logger.Info("starting server", "addr", server.Addr, "env", cfg.env)
-	err := server.ListenAndServe()
+	err = app.serve()
 	if err != nil {
 		logger.Error(err.Error())
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,14 @@
+package main
+
+import "net/http"
+
+func (app *application) routes() http.Handler {
+	router := http.NewServeMux()
+
+	// "/" also catches every path that doesn't match a more specific
+	// pattern below, so it doubles as our 404 handler.
+	router.HandleFunc("/", app.notFoundResponse)
+	router.HandleFunc("/healthcheck", app.healthCheckHandler)
+
+	return app.logRequest(app.recoverPanic(router))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// serve starts the public API server and the private debug server, and
+// blocks until both are shut down, either because ListenAndServe returns a
+// fatal error or because SIGINT/SIGTERM triggers a graceful shutdown. It
+// returns nil on a clean shutdown.
+func (app *application) serve() error {
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", app.config.Port),
+		Handler:      app.routes(),
+		IdleTimeout:  time.Minute,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		ErrorLog:     slog.NewLogLogger(app.logger.Handler(), slog.LevelError),
+	}
+
+	debugListener, err := net.Listen("tcp", app.config.DebugAddr)
+	if err != nil {
+		return fmt.Errorf("debug server: %w", err)
+	}
+
+	debugServer := &http.Server{
+		Handler:  app.debugRoutes(),
+		ErrorLog: slog.NewLogLogger(app.logger.Handler(), slog.LevelError),
+	}
+
+	shutdownError := make(chan error)
+
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+		sig := <-quit
+
+		app.logger.Info("caught signal, shutting down server", "addr", server.Addr, "env", app.config.Env, "signal", sig.String())
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.config.ShutdownTimeout)
+		defer cancel()
+
+		shutdownError <- errors.Join(server.Shutdown(shutdownCtx), debugServer.Shutdown(shutdownCtx))
+	}()
+
+	app.wg.Add(1)
+	go func() {
+		defer app.wg.Done()
+
+		app.logger.Info("starting debug server", "addr", debugListener.Addr().String(), "env", app.config.Env)
+
+		err := debugServer.Serve(debugListener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.logger.Error(err.Error())
+		}
+	}()
+
+	app.logger.Info("starting server", "addr", server.Addr, "env", app.config.Env)
+
+	err = server.ListenAndServe()
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	err = <-shutdownError
+	if err != nil {
+		return err
+	}
+
+	app.wg.Wait()
+
+	app.logger.Info("stopped server", "addr", server.Addr, "env", app.config.Env)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Package config resolves the application's configuration by layering
+// defaults, an optional .env file, environment variables, and finally
+// command-line flags, each overriding the last.
+package config
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds every resolved configuration value for the API process.
+type Config struct {
+	Port            int           `json:"port"`
+	Env             string        `json:"env"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+	DebugAddr       string        `json:"debug_addr"`
+
+	DB struct {
+		DSN string `json:"dsn"`
+	} `json:"db"`
+
+	CORS struct {
+		TrustedOrigins []string `json:"trusted_origins"`
+	} `json:"cors"`
+
+	Limiter struct {
+		RPS     float64 `json:"rps"`
+		Burst   int     `json:"burst"`
+		Enabled bool    `json:"enabled"`
+	} `json:"limiter"`
+
+	DumpConfig bool `json:"-"`
+}
+
+// validEnvs are the only values accepted for Config.Env.
+var validEnvs = map[string]bool{
+	"development": true,
+	"staging":     true,
+	"production":  true,
+}
+
+func defaults() Config {
+	var cfg Config
+
+	cfg.Port = 8080
+	cfg.Env = "development"
+	cfg.ShutdownTimeout = 20 * time.Second
+	cfg.DebugAddr = "localhost:0"
+	cfg.Limiter.RPS = 2
+	cfg.Limiter.Burst = 4
+	cfg.Limiter.Enabled = true
+
+	return cfg
+}
+
+// Load resolves the configuration in four layers, defaults, a .env file in
+// the working directory, environment variables, and command-line flags,
+// each taking precedence over the last. It validates the result and returns
+// an error if it's unusable.
+func Load() (Config, error) {
+	cfg := defaults()
+
+	loadDotEnv(".env")
+	applyEnv(&cfg)
+
+	dumpConfig := flag.Bool("dump-config", false, "Print the resolved configuration as JSON and exit")
+	flag.IntVar(&cfg.Port, "port", cfg.Port, "API server port")
+	flag.StringVar(&cfg.Env, "env", cfg.Env, "Environment (development|staging|production)")
+	flag.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "Timeout for graceful shutdown")
+	flag.StringVar(&cfg.DebugAddr, "debug-addr", cfg.DebugAddr, "Debug/admin server address (pprof, expvar)")
+	flag.StringVar(&cfg.DB.DSN, "db-dsn", cfg.DB.DSN, "PostgreSQL DSN")
+	flag.Func("cors-trusted-origins", "Space-separated list of trusted CORS origins", func(val string) error {
+		cfg.CORS.TrustedOrigins = strings.Fields(val)
+		return nil
+	})
+	flag.Float64Var(&cfg.Limiter.RPS, "limiter-rps", cfg.Limiter.RPS, "Rate limiter maximum requests per second")
+	flag.IntVar(&cfg.Limiter.Burst, "limiter-burst", cfg.Limiter.Burst, "Rate limiter maximum burst")
+	flag.BoolVar(&cfg.Limiter.Enabled, "limiter-enabled", cfg.Limiter.Enabled, "Enable the rate limiter")
+	flag.Parse()
+
+	cfg.DumpConfig = *dumpConfig
+
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg Config) validate() error {
+	if !validEnvs[cfg.Env] {
+		return fmt.Errorf("config: invalid env %q: must be one of development|staging|production", cfg.Env)
+	}
+
+	return nil
+}
+
+// applyEnv layers SORTR_* environment variables over cfg, overriding
+// whatever defaults or .env values are already set.
+func applyEnv(cfg *Config) {
+	if v, ok := os.LookupEnv("SORTR_PORT"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Port = n
+		}
+	}
+	if v, ok := os.LookupEnv("SORTR_ENV"); ok {
+		cfg.Env = v
+	}
+	if v, ok := os.LookupEnv("SORTR_SHUTDOWN_TIMEOUT"); ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.ShutdownTimeout = d
+		}
+	}
+	if v, ok := os.LookupEnv("SORTR_DEBUG_ADDR"); ok {
+		cfg.DebugAddr = v
+	}
+	if v, ok := os.LookupEnv("SORTR_DB_DSN"); ok {
+		cfg.DB.DSN = v
+	}
+	if v, ok := os.LookupEnv("SORTR_CORS_TRUSTED_ORIGINS"); ok {
+		cfg.CORS.TrustedOrigins = strings.Fields(v)
+	}
+	if v, ok := os.LookupEnv("SORTR_LIMITER_RPS"); ok {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Limiter.RPS = f
+		}
+	}
+	if v, ok := os.LookupEnv("SORTR_LIMITER_BURST"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Limiter.Burst = n
+		}
+	}
+	if v, ok := os.LookupEnv("SORTR_LIMITER_ENABLED"); ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Limiter.Enabled = b
+		}
+	}
+}
+
+// loadDotEnv parses a simple KEY=VALUE .env file and exports each entry via
+// os.Setenv, without overwriting variables already present in the
+// environment. Missing files are silently ignored.
+func loadDotEnv(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		os.Setenv(key, value)
+	}
+}
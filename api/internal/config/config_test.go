@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDefaults(t *testing.T) {
+	cfg := defaults()
+
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want 8080", cfg.Port)
+	}
+	if cfg.Env != "development" {
+		t.Errorf("Env = %q, want %q", cfg.Env, "development")
+	}
+	if cfg.ShutdownTimeout != 20*time.Second {
+		t.Errorf("ShutdownTimeout = %s, want %s", cfg.ShutdownTimeout, 20*time.Second)
+	}
+}
+
+func TestApplyEnvOverridesDefaults(t *testing.T) {
+	cfg := defaults()
+
+	t.Setenv("SORTR_PORT", "9090")
+	t.Setenv("SORTR_ENV", "production")
+	t.Setenv("SORTR_LIMITER_RPS", "5.5")
+	t.Setenv("SORTR_LIMITER_ENABLED", "false")
+	t.Setenv("SORTR_CORS_TRUSTED_ORIGINS", "https://a.example https://b.example")
+
+	applyEnv(&cfg)
+
+	if cfg.Port != 9090 {
+		t.Errorf("Port = %d, want 9090", cfg.Port)
+	}
+	if cfg.Env != "production" {
+		t.Errorf("Env = %q, want %q", cfg.Env, "production")
+	}
+	if cfg.Limiter.RPS != 5.5 {
+		t.Errorf("Limiter.RPS = %v, want 5.5", cfg.Limiter.RPS)
+	}
+	if cfg.Limiter.Enabled {
+		t.Error("Limiter.Enabled = true, want false")
+	}
+	want := []string{"https://a.example", "https://b.example"}
+	if len(cfg.CORS.TrustedOrigins) != len(want) || cfg.CORS.TrustedOrigins[0] != want[0] || cfg.CORS.TrustedOrigins[1] != want[1] {
+		t.Errorf("CORS.TrustedOrigins = %v, want %v", cfg.CORS.TrustedOrigins, want)
+	}
+}
+
+func TestApplyEnvLeavesUnsetFieldsAlone(t *testing.T) {
+	cfg := defaults()
+	applyEnv(&cfg)
+
+	if !reflect.DeepEqual(cfg, defaults()) {
+		t.Errorf("applyEnv changed cfg with no SORTR_* vars set: got %+v, want %+v", cfg, defaults())
+	}
+}
+
+func TestLoadDotEnvDoesNotOverrideExistingEnv(t *testing.T) {
+	t.Setenv("SORTR_ENV", "staging")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	contents := "SORTR_ENV=production\nSORTR_PORT=9999\n# a comment\n\nSORTR_DEBUG_ADDR=\"localhost:6060\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loadDotEnv(path)
+
+	cfg := defaults()
+	applyEnv(&cfg)
+
+	if cfg.Env != "staging" {
+		t.Errorf("Env = %q, want %q (pre-existing env var should win over .env)", cfg.Env, "staging")
+	}
+	if cfg.Port != 9999 {
+		t.Errorf("Port = %d, want 9999 (.env should fill in a var the environment didn't set)", cfg.Port)
+	}
+	if cfg.DebugAddr != "localhost:6060" {
+		t.Errorf("DebugAddr = %q, want %q (quoted .env values should be unquoted)", cfg.DebugAddr, "localhost:6060")
+	}
+}
+
+func TestLoadDotEnvMissingFileIsIgnored(t *testing.T) {
+	loadDotEnv(filepath.Join(t.TempDir(), "does-not-exist.env"))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		env     string
+		wantErr bool
+	}{
+		{"development", false},
+		{"staging", false},
+		{"production", false},
+		{"prod", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		cfg := defaults()
+		cfg.Env = tt.env
+
+		err := cfg.validate()
+		if tt.wantErr && err == nil {
+			t.Errorf("validate() with Env=%q: want error, got nil", tt.env)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("validate() with Env=%q: unexpected error: %v", tt.env, err)
+		}
+	}
+}